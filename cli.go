@@ -0,0 +1,257 @@
+package mybase
+
+import (
+	"fmt"
+	"strings"
+)
+
+// cliValue tracks how a single option was supplied on the command-line.
+type cliValue struct {
+	hasValue bool // true if an explicit value was attached, even if empty
+	value    string
+}
+
+// CommandLine represents a single invocation of a Command (the leaf command
+// reached after descending through any CommandSuite subcommand chain),
+// along with the raw values that were supplied for it directly on the
+// command-line. A *CommandLine satisfies Source, and is always given
+// highest priority by Config.
+type CommandLine struct {
+	Command      *Command
+	OriginalArgs []string
+
+	values    map[string]cliValue
+	onCLI     map[string]bool
+	argValues map[string]string
+}
+
+// OptionValue implements the Source interface. It only returns a value for
+// options that were supplied on the CLI with an explicit (possibly empty)
+// value -- options mentioned bare (e.g. a ValueOptional string option with
+// no attached value) fall through so that Config can consult other sources
+// or the option's Default instead.
+func (cli *CommandLine) OptionValue(name string) (string, bool) {
+	if cli == nil {
+		return "", false
+	}
+	if v, ok := cli.values[name]; ok && v.hasValue {
+		return v.value, true
+	}
+	return "", false
+}
+
+// Supplied returns true if name (an option or arg) was mentioned at all on
+// the command-line, regardless of whether an explicit value was attached.
+func (cli *CommandLine) Supplied(name string) bool {
+	if cli == nil {
+		return false
+	}
+	if cli.onCLI[name] {
+		return true
+	}
+	_, ok := cli.argValues[name]
+	return ok
+}
+
+// ParseCLI tokenizes and parses args (not including a leading program name)
+// against cmd, descending through any CommandSuite subcommand chain,
+// resolving options and positional args. It returns the *CommandLine for
+// the resolved leaf command.
+func ParseCLI(cmd *Command, args []string) (*CommandLine, error) {
+	cli := &CommandLine{
+		OriginalArgs: args,
+		values:       make(map[string]cliValue),
+		onCLI:        make(map[string]bool),
+		argValues:    make(map[string]string),
+	}
+
+	// Descend through any CommandSuite subcommand chain.
+	i := 0
+	for i < len(args) {
+		if strings.HasPrefix(args[i], "-") || cmd.SubCommands == nil {
+			break
+		}
+		sub, ok := cmd.SubCommands[args[i]]
+		if !ok {
+			break
+		}
+		cmd = sub
+		i++
+	}
+	cli.Command = cmd
+
+	var argTokens []string
+	for i < len(args) {
+		tok := args[i]
+		switch {
+		case strings.HasPrefix(tok, "--"):
+			consumed, err := parseLongOption(cmd, cli, tok, args[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			i += 1 + consumed
+		case strings.HasPrefix(tok, "-") && len(tok) > 1:
+			consumed, err := parseShortOption(cmd, cli, tok, args[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			i += 1 + consumed
+		default:
+			argTokens = append(argTokens, tok)
+			i++
+		}
+	}
+
+	for n, arg := range cmd.Args() {
+		if n < len(argTokens) {
+			cli.argValues[arg.Name] = argTokens[n]
+			cli.onCLI[arg.Name] = true
+		}
+	}
+
+	return cli, nil
+}
+
+func parseLongOption(cmd *Command, cli *CommandLine, tok string, rest []string) (consumed int, err error) {
+	name := strings.TrimPrefix(tok, "--")
+	var explicitValue string
+	hasExplicit := false
+	if eq := strings.IndexByte(name, '='); eq >= 0 {
+		explicitValue = name[eq+1:]
+		name = name[:eq]
+		hasExplicit = true
+	}
+
+	negated := false
+	lookupName := name
+	if strings.HasPrefix(name, "skip-") {
+		negated = true
+		lookupName = strings.TrimPrefix(name, "skip-")
+	}
+
+	opt, ok := cmd.OptionDefinition(lookupName)
+	if !ok {
+		return 0, fmt.Errorf("unknown option \"%s\"", name)
+	}
+
+	switch opt.Type {
+	case OptionTypeBool:
+		value := "true"
+		if negated {
+			value = "false"
+		}
+		cli.values[opt.Name] = cliValue{hasValue: true, value: value}
+		cli.onCLI[opt.Name] = true
+		return 0, nil
+	default: // OptionTypeString
+		cli.onCLI[opt.Name] = true
+		if hasExplicit {
+			value, err := resolveStringValue(opt, explicitValue)
+			if err != nil {
+				return 0, err
+			}
+			cli.values[opt.Name] = cliValue{hasValue: true, value: value}
+			return 0, nil
+		}
+		if opt.IsValueOptional() {
+			// Bare long flag never auto-consumes the next token.
+			cli.values[opt.Name] = cliValue{hasValue: false}
+			return 0, nil
+		}
+		if len(rest) == 0 {
+			return 0, fmt.Errorf("option \"%s\" requires a value", name)
+		}
+		value, err := resolveStringValue(opt, rest[0])
+		if err != nil {
+			return 0, err
+		}
+		cli.values[opt.Name] = cliValue{hasValue: true, value: value}
+		return 1, nil
+	}
+}
+
+func parseShortOption(cmd *Command, cli *CommandLine, tok string, rest []string) (consumed int, err error) {
+	shorthand := []rune(tok)[1]
+	opt, ok := findByShorthand(cmd, shorthand)
+	if !ok {
+		return 0, fmt.Errorf("unknown option \"-%c\"", shorthand)
+	}
+
+	switch opt.Type {
+	case OptionTypeBool:
+		cli.values[opt.Name] = cliValue{hasValue: true, value: "true"}
+		cli.onCLI[opt.Name] = true
+		return 0, nil
+	default: // OptionTypeString
+		cli.onCLI[opt.Name] = true
+		attached := string([]rune(tok)[2:])
+		if len(attached) > 0 {
+			value, err := resolveStringValue(opt, attached)
+			if err != nil {
+				return 0, err
+			}
+			cli.values[opt.Name] = cliValue{hasValue: true, value: value}
+			return 0, nil
+		}
+		if opt.IsValueOptional() {
+			// Bare short flag never auto-consumes the next token.
+			cli.values[opt.Name] = cliValue{hasValue: false}
+			return 0, nil
+		}
+		if len(rest) == 0 {
+			return 0, fmt.Errorf("option \"-%c\" requires a value", shorthand)
+		}
+		value, err := resolveStringValue(opt, rest[0])
+		if err != nil {
+			return 0, err
+		}
+		cli.values[opt.Name] = cliValue{hasValue: true, value: value}
+		return 1, nil
+	}
+}
+
+func findByShorthand(cmd *Command, shorthand rune) (*Option, bool) {
+	for c := cmd; c != nil; c = c.ParentCommand {
+		for _, opt := range c.options {
+			if opt.Shorthand == shorthand {
+				return opt, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// emptyMarker re-expresses an empty value as the canonical explicitly-empty
+// marker (an empty pair of quotes) so that it round-trips through
+// GetRaw/Get the same way an empty value from a quoted file-based Source
+// would.
+func emptyMarker(value string) string {
+	if value == "" {
+		return "''"
+	}
+	return value
+}
+
+// resolveStringValue validates value against opt's declared Choices (if
+// any), canonicalizing it to the declared casing, then applies emptyMarker.
+// An unrecognized value for an option with declared choices is a parse
+// error, rather than being deferred to a later GetEnum call.
+func resolveStringValue(opt *Option, value string) (string, error) {
+	if choices, ci := opt.ChoiceList(); len(choices) > 0 && value != "" {
+		canonical, ok := matchChoice(value, choices, ci)
+		if !ok {
+			return "", fmt.Errorf("value \"%s\" for option \"%s\" is not among its permitted choices %v", value, opt.Name, choices)
+		}
+		value = canonical
+	}
+	return emptyMarker(value), nil
+}
+
+func matchChoice(value string, choices []string, caseInsensitive bool) (string, bool) {
+	for _, choice := range choices {
+		if value == choice || (caseInsensitive && strings.EqualFold(value, choice)) {
+			return choice, true
+		}
+	}
+	return "", false
+}
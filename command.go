@@ -0,0 +1,116 @@
+package mybase
+
+// Arg represents a single positional (non-flag) command-line argument.
+type Arg struct {
+	Name     string
+	Default  string
+	Required bool
+}
+
+// Command represents a single CLI command: either a standalone command, or
+// one subcommand within a CommandSuite. Use NewCommand to construct a
+// standalone command or a CommandSuite's subcommand, and NewCommandSuite to
+// construct a command that has its own subcommands.
+type Command struct {
+	Name          string
+	Summary       string
+	Description   string
+	Handler       func(*Config) error
+	ParentCommand *Command
+	SubCommands   map[string]*Command
+
+	options     map[string]*Option
+	optionOrder []string
+	args        []*Arg
+}
+
+// NewCommand returns a new standalone Command (or, if later attached via
+// AddSubCommand, a subcommand of a CommandSuite). handler may be nil for
+// commands that are just used in tests, or whose dispatch is handled by the
+// caller directly.
+func NewCommand(name, summary, description string, handler func(*Config) error) *Command {
+	return &Command{
+		Name:        name,
+		Summary:     summary,
+		Description: description,
+		Handler:     handler,
+		options:     make(map[string]*Option),
+	}
+}
+
+// NewCommandSuite returns a new Command that is intended to have
+// subcommands attached via AddSubCommand, e.g. a top-level "mytool" command
+// with subcommands like "mytool create" and "mytool drop".
+func NewCommandSuite(name, summary, description string) *Command {
+	cmd := NewCommand(name, summary, description, nil)
+	cmd.SubCommands = make(map[string]*Command)
+	return cmd
+}
+
+// AddOption attaches opt to cmd. If cmd is a CommandSuite, the option is
+// inherited by all of its subcommands unless a subcommand defines its own
+// option of the same name.
+func (cmd *Command) AddOption(opt *Option) {
+	cmd.options[opt.Name] = opt
+	cmd.optionOrder = append(cmd.optionOrder, opt.Name)
+}
+
+// AddArg appends a new positional argument to cmd. Args are matched to
+// command-line tokens in the order they were added. Only the final arg may
+// be optional following other optional args; required args should
+// generally be added before optional ones.
+func (cmd *Command) AddArg(name, defaultValue string, required bool) {
+	cmd.args = append(cmd.args, &Arg{Name: name, Default: defaultValue, Required: required})
+}
+
+// AddSubCommand attaches sub to cmd as a subcommand, setting sub's
+// ParentCommand to cmd. If cmd was created via NewCommand rather than
+// NewCommandSuite, its SubCommands map is created on first use.
+func (cmd *Command) AddSubCommand(sub *Command) {
+	sub.ParentCommand = cmd
+	if cmd.SubCommands == nil {
+		cmd.SubCommands = make(map[string]*Command)
+	}
+	cmd.SubCommands[sub.Name] = sub
+}
+
+// OptionDefinitions returns all options defined directly on cmd, keyed by
+// name. It does not include options inherited from a parent CommandSuite.
+func (cmd *Command) OptionDefinitions() map[string]*Option {
+	return cmd.options
+}
+
+// Args returns the positional Arg definitions for cmd, in the order they
+// were added via AddArg.
+func (cmd *Command) Args() []*Arg {
+	return cmd.args
+}
+
+// OptionDefinition looks up name among cmd's own options, and failing that
+// walks up the ParentCommand chain (so subcommands inherit suite-level
+// options). The bool return indicates whether the option was found at all.
+func (cmd *Command) OptionDefinition(name string) (*Option, bool) {
+	for c := cmd; c != nil; c = c.ParentCommand {
+		if opt, ok := c.options[name]; ok {
+			return opt, true
+		}
+	}
+	return nil, false
+}
+
+// ArgDefinition looks up name among cmd's own positional args. Unlike
+// options, args are not inherited from a parent CommandSuite.
+func (cmd *Command) ArgDefinition(name string) (*Arg, bool) {
+	for _, arg := range cmd.args {
+		if arg.Name == name {
+			return arg, true
+		}
+	}
+	return nil, false
+}
+
+// HasArg returns true if cmd defines a positional arg with the given name.
+func (cmd *Command) HasArg(name string) bool {
+	_, ok := cmd.ArgDefinition(name)
+	return ok
+}
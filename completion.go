@@ -0,0 +1,168 @@
+package mybase
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// GenerateCompletion writes a shell completion script for cmd -- and, if
+// cmd is a CommandSuite, its direct subcommands -- to w. shell must be one
+// of "bash", "zsh", or "fish".
+func (cmd *Command) GenerateCompletion(shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return cmd.generateBashCompletion(w)
+	case "zsh":
+		return cmd.generateZshCompletion(w)
+	case "fish":
+		return cmd.generateFishCompletion(w)
+	default:
+		return fmt.Errorf("unsupported shell %q for completion generation", shell)
+	}
+}
+
+// ownOptionsSorted returns cmd's directly-defined options (not those
+// inherited from a parent CommandSuite), sorted by name for deterministic
+// output.
+func (cmd *Command) ownOptionsSorted() []*Option {
+	names := append([]string(nil), cmd.optionOrder...)
+	sort.Strings(names)
+	opts := make([]*Option, 0, len(names))
+	for _, name := range names {
+		opts = append(opts, cmd.options[name])
+	}
+	return opts
+}
+
+// completionWords returns, in a deterministic order, the subcommand names
+// followed by the --long/-s option forms (and any declared enum choices)
+// that a completion script should offer for cmd. Options are walked from
+// cmd up through its CommandSuite ancestry, same as OptionDefinition, so a
+// subcommand's completions include its suite's options too; a name defined
+// at more than one level is only listed once, for the most specific
+// (deepest) definition. Hidden options are excluded.
+func (cmd *Command) completionWords() []string {
+	var subNames []string
+	for name := range cmd.SubCommands {
+		subNames = append(subNames, name)
+	}
+	sort.Strings(subNames)
+
+	var optWords []string
+	seen := make(map[string]bool)
+	for c := cmd; c != nil; c = c.ParentCommand {
+		for _, opt := range c.ownOptionsSorted() {
+			if seen[opt.Name] {
+				continue
+			}
+			seen[opt.Name] = true
+			if opt.IsHidden() {
+				continue
+			}
+			optWords = append(optWords, "--"+opt.Name)
+			if opt.Shorthand != 0 {
+				optWords = append(optWords, "-"+string(opt.Shorthand))
+			}
+			if choices, _ := opt.ChoiceList(); len(choices) > 0 {
+				optWords = append(optWords, choices...)
+			}
+		}
+	}
+
+	return append(subNames, optWords...)
+}
+
+func (cmd *Command) generateBashCompletion(w io.Writer) error {
+	funcName := "_" + strings.ReplaceAll(cmd.Name, "-", "_") + "_completions"
+	_, err := fmt.Fprintf(w, "%s() {\n\tlocal cur\n\tCOMPREPLY=()\n\tcur=\"${COMP_WORDS[COMP_CWORD]}\"\n\tCOMPREPLY=( $(compgen -W \"%s\" -- \"$cur\") )\n}\ncomplete -F %s %s\n",
+		funcName, strings.Join(cmd.completionWords(), " "), funcName, cmd.Name)
+	return err
+}
+
+func (cmd *Command) generateZshCompletion(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "#compdef %s\n_arguments '*: :(%s)'\n", cmd.Name, strings.Join(cmd.completionWords(), " "))
+	return err
+}
+
+func (cmd *Command) generateFishCompletion(w io.Writer) error {
+	var sb strings.Builder
+	var subNames []string
+	for name := range cmd.SubCommands {
+		subNames = append(subNames, name)
+	}
+	sort.Strings(subNames)
+	for _, name := range subNames {
+		fmt.Fprintf(&sb, "complete -c %s -f -a %s\n", cmd.Name, name)
+	}
+	for _, opt := range cmd.ownOptionsSorted() {
+		if opt.IsHidden() {
+			continue
+		}
+		line := fmt.Sprintf("complete -c %s -l %s", cmd.Name, opt.Name)
+		if opt.Shorthand != 0 {
+			line += fmt.Sprintf(" -s %c", opt.Shorthand)
+		}
+		if opt.Description != "" {
+			line += fmt.Sprintf(" -d %q", opt.Description)
+		}
+		sb.WriteString(line + "\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// CompletionCandidates returns candidate completions for the partial word
+// buffer words (the tokens already typed on the command-line, with the
+// final element being the in-progress word) against the command tree
+// rooted at cmd. It powers the hidden --completion-args mode handled by
+// HandleCompletionArgs: subcommand names, --long/-s options (skipping
+// Hidden ones), and declared enum choices when the preceding word is the
+// --long form of an option that has them (a preceding -s short form is not
+// looked up).
+func CompletionCandidates(cmd *Command, words []string) []string {
+	for len(words) > 1 && cmd.SubCommands != nil {
+		sub, ok := cmd.SubCommands[words[0]]
+		if !ok {
+			break
+		}
+		cmd = sub
+		words = words[1:]
+	}
+
+	if len(words) >= 2 {
+		prevWord := words[len(words)-2]
+		if strings.HasPrefix(prevWord, "--") {
+			if opt, ok := cmd.OptionDefinition(strings.TrimPrefix(prevWord, "--")); ok {
+				if choices, _ := opt.ChoiceList(); len(choices) > 0 {
+					return choices
+				}
+			}
+		}
+	}
+
+	return cmd.completionWords()
+}
+
+// HandleCompletionArgs checks whether args begins with the hidden
+// "--completion-args" mode flag; if so, it writes one completion candidate
+// per line to w for the remaining words and returns true. The scripts that
+// GenerateCompletion produces carry a fixed word list and do not call this
+// mode themselves. A real binary's main() should call this
+// on its top-level Command before ParseCLI, and stop (without dispatching
+// normally) if it returns true, e.g.:
+//
+//	if cmd.HandleCompletionArgs(os.Args[1:], os.Stdout) {
+//		return
+//	}
+//	cli, err := ParseCLI(cmd, os.Args[1:])
+func (cmd *Command) HandleCompletionArgs(args []string, w io.Writer) bool {
+	if len(args) == 0 || args[0] != "--completion-args" {
+		return false
+	}
+	for _, candidate := range CompletionCandidates(cmd, args[1:]) {
+		fmt.Fprintln(w, candidate)
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package mybase
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateCompletionBash(t *testing.T) {
+	suite := simpleCommandSuite()
+
+	var buf strings.Builder
+	if err := suite.GenerateCompletion("bash", &buf); err != nil {
+		t.Fatalf("Unexpected error from GenerateCompletion: %s", err)
+	}
+
+	expected := "_mycommand_completions() {\n" +
+		"\tlocal cur\n" +
+		"\tCOMPREPLY=()\n" +
+		"\tcur=\"${COMP_WORDS[COMP_CWORD]}\"\n" +
+		"\tCOMPREPLY=( $(compgen -W \"one two --bool1 -b --bool2 -B --hasshort -s --truthybool --visible\" -- \"$cur\") )\n" +
+		"}\n" +
+		"complete -F _mycommand_completions mycommand\n"
+
+	if buf.String() != expected {
+		t.Errorf("Generated bash completion did not match expected.\n--- expected ---\n%s\n--- actual ---\n%s", expected, buf.String())
+	}
+}
+
+func TestGenerateCompletionUnsupportedShell(t *testing.T) {
+	suite := simpleCommandSuite()
+	var buf strings.Builder
+	if err := suite.GenerateCompletion("powershell", &buf); err == nil {
+		t.Error("Expected error for unsupported shell, instead got nil")
+	}
+}
+
+func TestGenerateCompletionZsh(t *testing.T) {
+	suite := simpleCommandSuite()
+
+	var buf strings.Builder
+	if err := suite.GenerateCompletion("zsh", &buf); err != nil {
+		t.Fatalf("Unexpected error from GenerateCompletion: %s", err)
+	}
+
+	expected := "#compdef mycommand\n" +
+		"_arguments '*: :(one two --bool1 -b --bool2 -B --hasshort -s --truthybool --visible)'\n"
+
+	if buf.String() != expected {
+		t.Errorf("Generated zsh completion did not match expected.\n--- expected ---\n%s\n--- actual ---\n%s", expected, buf.String())
+	}
+}
+
+func TestGenerateCompletionFish(t *testing.T) {
+	suite := simpleCommandSuite()
+
+	var buf strings.Builder
+	if err := suite.GenerateCompletion("fish", &buf); err != nil {
+		t.Fatalf("Unexpected error from GenerateCompletion: %s", err)
+	}
+
+	expected := "complete -c mycommand -f -a one\n" +
+		"complete -c mycommand -f -a two\n" +
+		"complete -c mycommand -l bool1 -s b -d \"dummy description\"\n" +
+		"complete -c mycommand -l bool2 -s B -d \"dummy description\"\n" +
+		"complete -c mycommand -l hasshort -s s -d \"dummy description\"\n" +
+		"complete -c mycommand -l truthybool -d \"dummy description\"\n" +
+		"complete -c mycommand -l visible -d \"dummy description\"\n"
+
+	if buf.String() != expected {
+		t.Errorf("Generated fish completion did not match expected.\n--- expected ---\n%s\n--- actual ---\n%s", expected, buf.String())
+	}
+}
+
+func TestCompletionCandidates(t *testing.T) {
+	suite := simpleCommandSuite()
+
+	top := CompletionCandidates(suite, []string{""})
+	expectedTop := []string{"one", "two", "--bool1", "-b", "--bool2", "-B", "--hasshort", "-s", "--truthybool", "--visible"}
+	if strings.Join(top, " ") != strings.Join(expectedTop, " ") {
+		t.Errorf("Expected top-level candidates %v, instead found %v", expectedTop, top)
+	}
+
+	sub := CompletionCandidates(suite, []string{"one", ""})
+	expectedSub := []string{"--hidden", "--newopt", "-n", "--visible", "--bool1", "-b", "--bool2", "-B", "--hasshort", "-s", "--truthybool"}
+	if strings.Join(sub, " ") != strings.Join(expectedSub, " ") {
+		t.Errorf("Expected subcommand candidates %v, instead found %v", expectedSub, sub)
+	}
+}
+
+func TestCompletionCandidatesChoices(t *testing.T) {
+	cmd := simpleCommand()
+	cmd.AddOption(StringOption("level", 0, "", "dummy description").Choices("low", "medium", "high"))
+
+	choices := CompletionCandidates(cmd, []string{"--level", ""})
+	expectedChoices := []string{"low", "medium", "high"}
+	if strings.Join(choices, " ") != strings.Join(expectedChoices, " ") {
+		t.Errorf("Expected --level candidates %v, instead found %v", expectedChoices, choices)
+	}
+}
+
+func TestHandleCompletionArgs(t *testing.T) {
+	suite := simpleCommandSuite()
+
+	var buf strings.Builder
+	if !suite.HandleCompletionArgs([]string{"--completion-args", "one", ""}, &buf) {
+		t.Fatal("Expected HandleCompletionArgs to return true for --completion-args, instead got false")
+	}
+	expected := "--hidden\n--newopt\n-n\n--visible\n--bool1\n-b\n--bool2\n-B\n--hasshort\n-s\n--truthybool\n"
+	if buf.String() != expected {
+		t.Errorf("Expected HandleCompletionArgs output %q, instead found %q", expected, buf.String())
+	}
+
+	var buf2 strings.Builder
+	if suite.HandleCompletionArgs([]string{"one"}, &buf2) {
+		t.Error("Expected HandleCompletionArgs to return false without the --completion-args flag, instead got true")
+	}
+	if buf2.String() != "" {
+		t.Errorf("Expected no output from HandleCompletionArgs without the --completion-args flag, instead found %q", buf2.String())
+	}
+}
@@ -0,0 +1,343 @@
+package mybase
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config represents the fully-resolved set of option values for a single
+// command invocation, combining the parsed CommandLine with zero or more
+// additional Sources (config files, environment variables, etc), layered in
+// the order they were supplied to NewConfig. The CommandLine always takes
+// priority over every other Source.
+type Config struct {
+	CLI     *CommandLine
+	Sources []Source
+}
+
+// NewConfig combines cli with the supplied sources (consulted in order,
+// after cli itself) into a single Config.
+func NewConfig(cli *CommandLine, sources ...Source) *Config {
+	return &Config{CLI: cli, Sources: sources}
+}
+
+func (cfg *Config) option(name string) (*Option, bool) {
+	if cfg.CLI == nil || cfg.CLI.Command == nil {
+		return nil, false
+	}
+	return cfg.CLI.Command.OptionDefinition(name)
+}
+
+func (cfg *Config) arg(name string) (*Arg, bool) {
+	if cfg.CLI == nil || cfg.CLI.Command == nil {
+		return nil, false
+	}
+	return cfg.CLI.Command.ArgDefinition(name)
+}
+
+func (cfg *Config) defaultFor(name string) string {
+	if opt, ok := cfg.option(name); ok {
+		return opt.Default
+	}
+	if arg, ok := cfg.arg(name); ok {
+		return arg.Default
+	}
+	return ""
+}
+
+// GetRaw returns the raw (still potentially quoted/escaped) value of the
+// named option or arg, without any further processing. It consults the
+// CommandLine first, then each Source in the order given to NewConfig,
+// falling back to the option's (or arg's) Default if nothing else supplied
+// a value.
+func (cfg *Config) GetRaw(name string) string {
+	if v, ok := cfg.CLI.OptionValue(name); ok {
+		return v
+	}
+	if cfg.CLI != nil {
+		if v, ok := cfg.CLI.argValues[name]; ok {
+			return v
+		}
+	}
+	for _, src := range cfg.Sources {
+		if v, ok := src.OptionValue(name); ok {
+			return v
+		}
+	}
+	return cfg.defaultFor(name)
+}
+
+// Get returns the fully-resolved string value of the named option or arg,
+// with any wrapping quotes and backslash-escapes removed via the same rules
+// as config files use. For a bool-typed option, the value is additionally
+// normalized to the canonical "true"/"false", so that sources other than
+// the CLI itself (which can only ever supply those two strings) -- an
+// EnvSource, say -- may use friendlier spellings like "1"/"0" or "yes"/"no".
+// For an option with declared Choices, the value is canonicalized to the
+// declared casing, the same as already happens for values supplied on the
+// CLI -- this way Get's result doesn't vary in casing depending on which
+// layer (CLI, file, env) happened to supply it.
+func (cfg *Config) Get(name string) string {
+	value := unquote(cfg.GetRaw(name))
+	if opt, ok := cfg.option(name); ok {
+		switch opt.Type {
+		case OptionTypeBool:
+			value = normalizeBool(value)
+		default:
+			if choices, ci := opt.ChoiceList(); len(choices) > 0 && value != "" {
+				if canonical, ok := matchChoice(value, choices, ci); ok {
+					value = canonical
+				}
+			}
+		}
+	}
+	return value
+}
+
+// normalizeBool maps the handful of common truthy/falsy spellings allowed
+// by non-CLI Sources onto the canonical "true"/"false" strings the rest of
+// this package expects. Anything unrecognized is returned unchanged.
+func normalizeBool(raw string) string {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "1", "true", "yes":
+		return "true"
+	case "0", "false", "no":
+		return "false"
+	default:
+		return raw
+	}
+}
+
+// Changed returns true if the resolved value of name differs from its
+// Option's (or Arg's) Default.
+func (cfg *Config) Changed(name string) bool {
+	return cfg.Get(name) != cfg.defaultFor(name)
+}
+
+// Supplied returns true if name was supplied a value by some layer of
+// configuration -- the command-line, or any other Source -- as opposed to
+// just falling back to its Default.
+func (cfg *Config) Supplied(name string) bool {
+	if cfg.CLI != nil && cfg.CLI.Supplied(name) {
+		return true
+	}
+	for _, src := range cfg.Sources {
+		if _, ok := src.OptionValue(name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// OnCLI returns true if name was mentioned directly on the command-line,
+// regardless of whether any other Source also supplies a value for it.
+func (cfg *Config) OnCLI(name string) bool {
+	if cfg.CLI == nil {
+		return false
+	}
+	if cfg.CLI.onCLI[name] {
+		return true
+	}
+	_, ok := cfg.CLI.argValues[name]
+	return ok
+}
+
+// SuppliedWithValue returns true if name -- which must refer to a string
+// Option with ValueOptional() set -- was supplied an explicit value (as
+// opposed to being supplied bare, in which case its Default is used). It
+// panics if name isn't a known, string-typed, value-optional option.
+func (cfg *Config) SuppliedWithValue(name string) bool {
+	opt, ok := cfg.option(name)
+	if !ok {
+		panic(fmt.Errorf("SuppliedWithValue: option \"%s\" does not exist", name))
+	}
+	if opt.Type != OptionTypeString {
+		panic(fmt.Errorf("SuppliedWithValue: option \"%s\" is not string-typed", name))
+	}
+	if !opt.IsValueOptional() {
+		panic(fmt.Errorf("SuppliedWithValue: option \"%s\" does not have an optional value", name))
+	}
+
+	if cfg.CLI != nil {
+		if v, ok := cfg.CLI.values[name]; ok {
+			return v.hasValue
+		}
+	}
+	for _, src := range cfg.Sources {
+		if _, ok := src.OptionValue(name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSlice splits the named option's value on delimiter, respecting quoted
+// (', ", or `) sub-strings and backslash-escapes the same way Get() does
+// for a single value. If unwrapFull is true, the entire raw value is first
+// unwrapped as a single quoted string (if it is one) before splitting --
+// this allows a delimiter-bearing value to be fully quoted as a whole,
+// rather than each element needing individual quoting.
+func (cfg *Config) GetSlice(name string, delimiter rune, unwrapFull bool) []string {
+	raw := strings.TrimSpace(cfg.GetRaw(name))
+	if unwrapFull {
+		raw = strings.TrimSpace(unquote(raw))
+	}
+	if raw == "" {
+		return []string{}
+	}
+
+	rawFields := splitRespectingQuotes(raw, delimiter)
+	result := make([]string, 0, len(rawFields))
+	for _, field := range rawFields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			// Nothing at all between delimiters (e.g. repeated spaces) --
+			// collapse it away rather than producing a spurious empty
+			// element. A field the caller deliberately quoted as empty
+			// (e.g. '') is non-empty at this point and survives below.
+			continue
+		}
+		field = strings.TrimSpace(unquote(field))
+		result = append(result, field)
+	}
+	return result
+}
+
+// GetEnum returns the value of the named option, validated against the
+// supplied list of allowed values case-insensitively. If the option has its
+// own declared Choices (see Option.Choices), those are used instead and the
+// allowed arg here becomes redundant documentation; either way, an error is
+// returned if the current value isn't in the allowed set, unless the value
+// is blank (which is always permitted, matching the Default-is-empty
+// convention used elsewhere in this package).
+func (cfg *Config) GetEnum(name string, allowed ...string) (string, error) {
+	if opt, ok := cfg.option(name); ok {
+		if choices, _ := opt.ChoiceList(); len(choices) > 0 {
+			allowed = choices
+		}
+	}
+
+	value := cfg.Get(name)
+	if value == "" {
+		return "", nil
+	}
+	for _, candidate := range allowed {
+		if strings.EqualFold(value, candidate) {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("value \"%s\" for option \"%s\" is not among its permitted values", value, name)
+}
+
+// GetBytes parses the named option's value as a byte quantity, permitting a
+// trailing k/K, m/M/mb/MB, or g/G/gb/GB suffix (powers of 1024). A blank
+// value returns 0 with no error.
+func (cfg *Config) GetBytes(name string) (uint64, error) {
+	value := strings.TrimSpace(cfg.Get(name))
+	if value == "" {
+		return 0, nil
+	}
+
+	multiplier := uint64(1)
+	lower := strings.ToLower(value)
+	switch {
+	case strings.HasSuffix(lower, "gb"):
+		multiplier = 1024 * 1024 * 1024
+		value = value[:len(value)-2]
+	case strings.HasSuffix(lower, "mb"):
+		multiplier = 1024 * 1024
+		value = value[:len(value)-2]
+	case strings.HasSuffix(lower, "g"):
+		multiplier = 1024 * 1024 * 1024
+		value = value[:len(value)-1]
+	case strings.HasSuffix(lower, "m"):
+		multiplier = 1024 * 1024
+		value = value[:len(value)-1]
+	case strings.HasSuffix(lower, "k"):
+		multiplier = 1024
+		value = value[:len(value)-1]
+	}
+
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("value \"%s\" for option \"%s\" cannot be parsed as a byte size: %s", cfg.Get(name), name, err)
+	}
+	return n * multiplier, nil
+}
+
+// GetRegexp compiles the named option's value as a regular expression. A
+// blank value returns nil, nil.
+func (cfg *Config) GetRegexp(name string) (*regexp.Regexp, error) {
+	value := cfg.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(value)
+	if err != nil {
+		return nil, fmt.Errorf("value \"%s\" for option \"%s\" is not a valid regular expression: %s", value, name, err)
+	}
+	return re, nil
+}
+
+// GetDuration parses the named option's value as a time.Duration, accepting
+// normal Go duration syntax (e.g. "90m") as well as the friendlier
+// whole-value suffixes "d" (24h) and "w" (168h), e.g. "1d" or "2w". A blank
+// value returns 0 with no error, matching GetBytes. Negative durations are
+// rejected.
+func (cfg *Config) GetDuration(name string) (time.Duration, error) {
+	value := strings.TrimSpace(cfg.Get(name))
+	if value == "" {
+		return 0, nil
+	}
+
+	var d time.Duration
+	switch {
+	case strings.HasSuffix(value, "d"):
+		n, err := strconv.ParseFloat(value[:len(value)-1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("value \"%s\" for option \"%s\" cannot be parsed as a duration: %s", value, name, err)
+		}
+		d = time.Duration(n * float64(24*time.Hour))
+	case strings.HasSuffix(value, "w"):
+		n, err := strconv.ParseFloat(value[:len(value)-1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("value \"%s\" for option \"%s\" cannot be parsed as a duration: %s", value, name, err)
+		}
+		d = time.Duration(n * float64(7*24*time.Hour))
+	default:
+		var err error
+		d, err = time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("value \"%s\" for option \"%s\" cannot be parsed as a duration: %s", value, name, err)
+		}
+	}
+
+	if d < 0 {
+		return 0, fmt.Errorf("value \"%s\" for option \"%s\" cannot be a negative duration", value, name)
+	}
+	return d, nil
+}
+
+// GetTime parses the named option's value as a time.Time, trying RFC3339
+// first and then, in order, any layouts supplied by the caller. A blank
+// value returns the zero time with no error.
+func (cfg *Config) GetTime(name string, layouts ...string) (time.Time, error) {
+	value := strings.TrimSpace(cfg.Get(name))
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	allLayouts := append([]string{time.RFC3339}, layouts...)
+	var lastErr error
+	for _, layout := range allLayouts {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+		lastErr = err
+	}
+	return time.Time{}, fmt.Errorf("value \"%s\" for option \"%s\" cannot be parsed as a time: %s", value, name, lastErr)
+}
@@ -0,0 +1,18 @@
+package mybase
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestGetSliceExplicitEmptyField confirms that a field the caller
+// deliberately quoted as an empty string is preserved as an empty string
+// element, distinct from incidental empty fields produced by repeated
+// delimiters, which are collapsed away instead.
+func TestGetSliceExplicitEmptyField(t *testing.T) {
+	cfg := simpleConfig(map[string]string{"option-name": `a, '', b`})
+	expected := []string{"a", "", "b"}
+	if actual := cfg.GetSlice("option-name", ',', false); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected GetSlice to preserve a deliberately-quoted empty field as %#v, instead found %#v", expected, actual)
+	}
+}
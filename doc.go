@@ -0,0 +1,6 @@
+// Package mybase provides a layered configuration system for command-line
+// tools: options may be defined once on a Command (or CommandSuite) and
+// then resolved from several Sources -- the command-line itself, config
+// files, environment variables, and so on -- with a well-defined priority
+// order, through a single Config.
+package mybase
@@ -0,0 +1,73 @@
+package mybase
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetDuration(t *testing.T) {
+	optionValues := map[string]string{
+		"mins-ok":       "90m",
+		"days-ok":       "1d",
+		"weeks-ok":      "2w",
+		"invalid-fail":  "3x",
+		"negative-fail": "-5m",
+		"blank-ok":      "",
+	}
+	cfg := simpleConfig(optionValues)
+
+	assertDuration := func(name string, expect time.Duration) {
+		t.Helper()
+		value, err := cfg.GetDuration(name)
+		if err == nil && strings.HasSuffix(name, "-fail") {
+			t.Errorf("Expected error for GetDuration(%s) but didn't find one", name)
+		} else if err != nil && strings.HasSuffix(name, "-ok") {
+			t.Errorf("Unexpected error for GetDuration(%s): %s", name, err)
+		}
+		if value != expect {
+			t.Errorf("Expected GetDuration(%s) to return %s, instead found %s", name, expect, value)
+		}
+	}
+
+	expected := map[string]time.Duration{
+		"mins-ok":       90 * time.Minute,
+		"days-ok":       24 * time.Hour,
+		"weeks-ok":      2 * 7 * 24 * time.Hour,
+		"invalid-fail":  0,
+		"negative-fail": 0,
+		"blank-ok":      0,
+	}
+	for name, expect := range expected {
+		assertDuration(name, expect)
+	}
+}
+
+func TestGetTime(t *testing.T) {
+	optionValues := map[string]string{
+		"rfc3339-ok": "2021-06-01T15:04:05Z",
+		"custom-ok":  "2021-06-01 15:04:05",
+		"blank-ok":   "",
+		"bogus-fail": "not-a-time",
+	}
+	cfg := simpleConfig(optionValues)
+
+	value, err := cfg.GetTime("rfc3339-ok")
+	if err != nil || !value.Equal(time.Date(2021, 6, 1, 15, 4, 5, 0, time.UTC)) {
+		t.Errorf("Unexpected result from GetTime(\"rfc3339-ok\"): %v, %s", value, err)
+	}
+
+	value, err = cfg.GetTime("custom-ok", "2006-01-02 15:04:05")
+	if err != nil || !value.Equal(time.Date(2021, 6, 1, 15, 4, 5, 0, time.UTC)) {
+		t.Errorf("Unexpected result from GetTime(\"custom-ok\", ...): %v, %s", value, err)
+	}
+
+	value, err = cfg.GetTime("blank-ok")
+	if err != nil || !value.IsZero() {
+		t.Errorf("Expected blank GetTime to return the zero time with no error; instead found %v, %s", value, err)
+	}
+
+	if _, err = cfg.GetTime("bogus-fail"); err == nil {
+		t.Error("Expected error from GetTime(\"bogus-fail\"), instead found nil")
+	}
+}
@@ -0,0 +1,57 @@
+package mybase
+
+import (
+	"os"
+	"strings"
+)
+
+// EnvSource is a Source that resolves an option's value by consulting an
+// environment variable derived from its name, constructed via NewEnvSource.
+type EnvSource struct {
+	prefix       string
+	separator    string
+	preserveCase bool
+}
+
+// NewEnvSource returns a Source that resolves any option lookup "foo-bar" by
+// consulting PREFIX_FOO_BAR in the process environment. Use Separator to
+// change the "_" joining prefix, option name words, and (for options whose
+// name already contains hyphens) the mangled word boundaries; use
+// PreserveCase to stop upper-casing the variable name.
+//
+// It's intended to be layered into NewConfig below the command-line itself
+// but above any file-based Sources, e.g. NewConfig(cli, NewEnvSource("MYAPP"),
+// iniSource) -- so cfg.Supplied("x") is true when only the env var supplied
+// a value, while cfg.OnCLI("x") remains false.
+func NewEnvSource(prefix string) *EnvSource {
+	return &EnvSource{prefix: prefix, separator: "_"}
+}
+
+// Separator changes the string used to join the prefix to the mangled
+// option name, and to replace hyphens within the option name itself.
+// Returns the receiver for chaining.
+func (s *EnvSource) Separator(separator string) *EnvSource {
+	s.separator = separator
+	return s
+}
+
+// PreserveCase stops EnvSource from upper-casing the constructed
+// environment variable name. Returns the receiver for chaining.
+func (s *EnvSource) PreserveCase() *EnvSource {
+	s.preserveCase = true
+	return s
+}
+
+func (s *EnvSource) envVarName(optionName string) string {
+	mangled := strings.ReplaceAll(optionName, "-", s.separator)
+	name := s.prefix + s.separator + mangled
+	if !s.preserveCase {
+		name = strings.ToUpper(name)
+	}
+	return name
+}
+
+// OptionValue implements the Source interface.
+func (s *EnvSource) OptionValue(optionName string) (string, bool) {
+	return os.LookupEnv(s.envVarName(optionName))
+}
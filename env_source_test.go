@@ -0,0 +1,90 @@
+package mybase
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestEnvSourceOptionStatus(t *testing.T) {
+	os.Setenv("MYAPP_VISIBLE", "from env")
+	os.Setenv("MYAPP_BOOL1", "yes")
+	defer os.Unsetenv("MYAPP_VISIBLE")
+	defer os.Unsetenv("MYAPP_BOOL1")
+
+	cmd := simpleCommand()
+	env := NewEnvSource("MYAPP")
+	cfg := ParseFakeCLI(t, cmd, "mycommand -s 'hello world' --skip-truthybool arg1", env)
+
+	assertOptionStatus := func(name string, expectChanged, expectSupplied, expectOnCLI bool) {
+		t.Helper()
+		if cfg.Changed(name) != expectChanged {
+			t.Errorf("Expected cfg.Changed(%s)==%t, but instead returned %t", name, expectChanged, !expectChanged)
+		}
+		if cfg.Supplied(name) != expectSupplied {
+			t.Errorf("Expected cfg.Supplied(%s)==%t, but instead returned %t", name, expectSupplied, !expectSupplied)
+		}
+		if cfg.OnCLI(name) != expectOnCLI {
+			t.Errorf("Expected cfg.OnCLI(%s)==%t, but instead returned %t", name, expectOnCLI, !expectOnCLI)
+		}
+	}
+
+	// visible and bool1 come only from the env var layered beneath the CLI
+	assertOptionStatus("visible", true, true, false)
+	assertOptionStatus("bool1", true, true, false)
+	// hasshort and truthybool still come from the CLI itself, same as without an EnvSource
+	assertOptionStatus("hasshort", true, true, true)
+	assertOptionStatus("truthybool", true, true, true)
+
+	// "yes" is one of the friendly bool spellings normalized by Config.Get
+	if value := cfg.Get("bool1"); value != "true" {
+		t.Errorf("Expected Get(\"bool1\") to normalize env value \"yes\" to \"true\", instead found %q", value)
+	}
+}
+
+func TestEnvSourceNaming(t *testing.T) {
+	os.Setenv("MYAPP_MAX_RETRIES", "5")
+	os.Setenv("myapp_lower_opt", "lowercase value")
+	os.Setenv("MYAPP-DASHED-OPT", "dashed value")
+	defer os.Unsetenv("MYAPP_MAX_RETRIES")
+	defer os.Unsetenv("myapp_lower_opt")
+	defer os.Unsetenv("MYAPP-DASHED-OPT")
+
+	cmd := NewCommand("mycommand", "summary", "description", nil)
+	cmd.AddOption(StringOption("max-retries", 0, "", "dummy description"))
+	cmd.AddOption(StringOption("lower-opt", 0, "", "dummy description"))
+	cmd.AddOption(StringOption("dashed-opt", 0, "", "dummy description"))
+
+	env := NewEnvSource("MYAPP")
+	cfg := ParseFakeCLI(t, cmd, "mycommand", env)
+	if value := cfg.Get("max-retries"); value != "5" {
+		t.Errorf("Expected default separator/casing to find MYAPP_MAX_RETRIES, instead found %q", value)
+	}
+
+	lowerEnv := NewEnvSource("myapp").PreserveCase()
+	cfg = ParseFakeCLI(t, cmd, "mycommand", lowerEnv)
+	if value := cfg.Get("lower-opt"); value != "lowercase value" {
+		t.Errorf("Expected PreserveCase() to find myapp_lower_opt verbatim, instead found %q", value)
+	}
+
+	dashedEnv := NewEnvSource("MYAPP").Separator("-")
+	cfg = ParseFakeCLI(t, cmd, "mycommand", dashedEnv)
+	if value := cfg.Get("dashed-opt"); value != "dashed value" {
+		t.Errorf("Expected Separator(\"-\") to find MYAPP-DASHED-OPT, instead found %q", value)
+	}
+}
+
+func TestEnvSourceSlice(t *testing.T) {
+	os.Setenv("MYAPP_TAGS", "a,b, c")
+	defer os.Unsetenv("MYAPP_TAGS")
+
+	cmd := NewCommand("mycommand", "summary", "description", nil)
+	cmd.AddOption(StringOption("tags", 0, "", "dummy description"))
+	env := NewEnvSource("MYAPP")
+	cfg := ParseFakeCLI(t, cmd, "mycommand", env)
+
+	expected := []string{"a", "b", "c"}
+	if actual := cfg.GetSlice("tags", ',', false); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected GetSlice(\"tags\",...) to return %#v, instead found %#v", expected, actual)
+	}
+}
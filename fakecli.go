@@ -0,0 +1,65 @@
+package mybase
+
+import (
+	"strings"
+	"testing"
+)
+
+// ParseFakeCLI simulates parsing cliString (a full command-line, including
+// the invoked command name, as a human would type it at a shell) against
+// cmd, as if it had been the real process arguments. It's intended for use
+// in tests of code that consumes a *Config, sparing callers from having to
+// build a CommandLine by hand. Any extraSources are layered beneath the
+// simulated command-line, exactly as with NewConfig.
+func ParseFakeCLI(t *testing.T, cmd *Command, cliString string, extraSources ...Source) *Config {
+	t.Helper()
+	tokens := shellSplit(cliString)
+	if len(tokens) == 0 {
+		t.Fatalf("ParseFakeCLI: cliString %q did not tokenize to anything", cliString)
+	}
+
+	cli, err := ParseCLI(cmd, tokens[1:])
+	if err != nil {
+		t.Fatalf("ParseFakeCLI: unexpected error parsing %q: %s", cliString, err)
+	}
+	return NewConfig(cli, extraSources...)
+}
+
+// shellSplit tokenizes s the way a POSIX shell would split a command line
+// into argv, including removal of single and double quote characters used
+// purely to group whitespace (or to wrap an otherwise-empty value). It does
+// not support the full generality of shell quoting (nested expansions,
+// etc), which mybase's tests have no need for.
+func shellSplit(s string) []string {
+	var tokens []string
+	var cur strings.Builder
+	var quote rune
+	inToken := false
+
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inToken = true
+		case r == ' ' || r == '\t':
+			if inToken {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+				inToken = false
+			}
+		default:
+			cur.WriteRune(r)
+			inToken = true
+		}
+	}
+	if inToken {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens
+}
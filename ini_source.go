@@ -0,0 +1,142 @@
+package mybase
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IniSource is a Source that resolves option values from an INI-formatted
+// file. Top-level "key = value" pairs apply to the root Command; a
+// "[section]" header scopes its keys to the identically-named subcommand,
+// and a dotted "[parent.child]" header scopes to a nested subcommand within
+// a CommandSuite. An "include=path" key (relative to the including file's
+// directory, unless absolute) splices in another INI file at that point,
+// recursively; it's valid in any section.
+//
+// Values feed through the same quote/escape handling as any other Source,
+// via Config.Get/Config.GetRaw -- an IniSource just supplies raw strings,
+// same as SimpleSource.
+type IniSource struct {
+	values map[string]string
+}
+
+// NewIniSource parses the INI file at path, scoped to the command chain
+// resolved by cli (i.e. cli.Command and its CommandSuite ancestry), and
+// returns a Source usable with NewConfig. It is an error for a key (other
+// than "include") to not correspond to a valid Option -- including Hidden
+// ones -- on the section's target command.
+func NewIniSource(path string, cli *CommandLine) (*IniSource, error) {
+	values := make(map[string]string)
+	if err := parseIniFile(path, cli.Command, rootOf(cli.Command), values, make(map[string]bool)); err != nil {
+		return nil, err
+	}
+	return &IniSource{values: values}, nil
+}
+
+// OptionValue implements the Source interface.
+func (s *IniSource) OptionValue(name string) (string, bool) {
+	value, ok := s.values[name]
+	return value, ok
+}
+
+func parseIniFile(path string, leaf *Command, scope *Command, values map[string]string, seen map[string]bool) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("IniSource: cannot resolve path %s: %w", path, err)
+	}
+	if seen[abs] {
+		return nil // already included; avoid infinite include cycles
+	}
+	seen[abs] = true
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("IniSource: cannot open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	lineNum := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			sectionName := strings.TrimSpace(line[1 : len(line)-1])
+			next, ok := resolveSection(rootOf(leaf), sectionName)
+			if !ok {
+				return fmt.Errorf("%s:%d: [%s] does not correspond to any subcommand", path, lineNum, sectionName)
+			}
+			scope = next
+			continue
+		}
+
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 {
+			return fmt.Errorf("%s:%d: expected \"key = value\", found %q", path, lineNum, line)
+		}
+		key := strings.TrimSpace(line[:eq])
+		value := strings.TrimSpace(line[eq+1:])
+
+		if key == "include" {
+			includePath := value
+			if !filepath.IsAbs(includePath) {
+				includePath = filepath.Join(filepath.Dir(path), includePath)
+			}
+			if err := parseIniFile(includePath, leaf, scope, values, seen); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, ok := scope.OptionDefinition(key); !ok {
+			return fmt.Errorf("%s:%d: %q is not a recognized option for command %q", path, lineNum, key, scope.Name)
+		}
+		if isAncestorOrSelf(scope, leaf) {
+			values[key] = value
+		}
+	}
+	return scanner.Err()
+}
+
+func rootOf(cmd *Command) *Command {
+	for cmd.ParentCommand != nil {
+		cmd = cmd.ParentCommand
+	}
+	return cmd
+}
+
+// resolveSection walks a dotted section name ("parent.child") from root
+// through successive SubCommands maps.
+func resolveSection(root *Command, sectionName string) (*Command, bool) {
+	cur := root
+	for _, part := range strings.Split(sectionName, ".") {
+		if cur.SubCommands == nil {
+			return nil, false
+		}
+		next, ok := cur.SubCommands[part]
+		if !ok {
+			return nil, false
+		}
+		cur = next
+	}
+	return cur, true
+}
+
+// isAncestorOrSelf returns true if scope is leaf itself or one of its
+// CommandSuite ancestors -- i.e. whether an option scoped to scope should
+// be visible to leaf.
+func isAncestorOrSelf(scope, leaf *Command) bool {
+	for c := leaf; c != nil; c = c.ParentCommand {
+		if c == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package mybase
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIniSourceOptionStatus(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.ini")
+	contents := "visible = from ini\nbool1 = 1\n"
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("Unable to write temp INI file: %s", err)
+	}
+
+	cmd := simpleCommand()
+	cli, err := ParseCLI(cmd, []string{"-s", "hello world", "--skip-truthybool", "arg1"})
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseCLI: %s", err)
+	}
+	ini, err := NewIniSource(path, cli)
+	if err != nil {
+		t.Fatalf("Unexpected error from NewIniSource: %s", err)
+	}
+	cfg := NewConfig(cli, ini)
+
+	assertOptionStatus := func(name string, expectChanged, expectSupplied, expectOnCLI bool) {
+		t.Helper()
+		if cfg.Changed(name) != expectChanged {
+			t.Errorf("Expected cfg.Changed(%s)==%t, but instead returned %t", name, expectChanged, !expectChanged)
+		}
+		if cfg.Supplied(name) != expectSupplied {
+			t.Errorf("Expected cfg.Supplied(%s)==%t, but instead returned %t", name, expectSupplied, !expectSupplied)
+		}
+		if cfg.OnCLI(name) != expectOnCLI {
+			t.Errorf("Expected cfg.OnCLI(%s)==%t, but instead returned %t", name, expectOnCLI, !expectOnCLI)
+		}
+	}
+
+	// visible and bool1 come only from the INI source layered beneath the CLI
+	assertOptionStatus("visible", true, true, false)
+	assertOptionStatus("bool1", true, true, false)
+	// hasshort and truthybool still come from the CLI itself, same as without an IniSource
+	assertOptionStatus("hasshort", true, true, true)
+	assertOptionStatus("truthybool", true, true, true)
+}
+
+func TestIniSourceSections(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.ini")
+	contents := "" +
+		"visible = suite-level\n" +
+		"[one]\n" +
+		"newopt = from one section\n"
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("Unable to write temp INI file: %s", err)
+	}
+
+	suite := simpleCommandSuite()
+	cli, err := ParseCLI(suite, []string{"one"})
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseCLI: %s", err)
+	}
+	ini, err := NewIniSource(path, cli)
+	if err != nil {
+		t.Fatalf("Unexpected error from NewIniSource: %s", err)
+	}
+	cfg := NewConfig(cli, ini)
+
+	if value := cfg.Get("visible"); value != "suite-level" {
+		t.Errorf("Expected suite-level [root] key to apply to subcommand \"one\", instead found %q", value)
+	}
+	if value := cfg.Get("newopt"); value != "from one section" {
+		t.Errorf("Expected [one]-scoped key to apply to subcommand \"one\", instead found %q", value)
+	}
+}
+
+func TestIniSourceUnknownSection(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.ini")
+	if err := os.WriteFile(path, []byte("[nosuchsub]\nnewopt = whatever\n"), 0644); err != nil {
+		t.Fatalf("Unable to write temp INI file: %s", err)
+	}
+
+	suite := simpleCommandSuite()
+	cli, err := ParseCLI(suite, []string{"one"})
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseCLI: %s", err)
+	}
+	if _, err := NewIniSource(path, cli); err == nil {
+		t.Error("Expected error from NewIniSource with a section naming a nonexistent subcommand, instead got nil")
+	}
+}
+
+func TestIniSourceDottedSection(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.ini")
+	contents := "[one.nested]\nnewopt = dotted section value\n"
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("Unable to write temp INI file: %s", err)
+	}
+
+	suite := simpleCommandSuite()
+	cmd1, _ := suite.SubCommands["one"]
+	nested := NewCommand("nested", "summary", "description", nil)
+	nested.AddOption(StringOption("newopt", 0, "", "dummy description"))
+	cmd1.AddSubCommand(nested)
+
+	cli, err := ParseCLI(suite, []string{"one", "nested"})
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseCLI: %s", err)
+	}
+	ini, err := NewIniSource(path, cli)
+	if err != nil {
+		t.Fatalf("Unexpected error from NewIniSource: %s", err)
+	}
+	cfg := NewConfig(cli, ini)
+
+	if value := cfg.Get("newopt"); value != "dotted section value" {
+		t.Errorf("Expected dotted [one.nested] section to scope to the nested subcommand, instead found %q", value)
+	}
+}
+
+func TestIniSourceInvalidOption(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.ini")
+	contents := "notanoption = whatever\n"
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("Unable to write temp INI file: %s", err)
+	}
+
+	cmd := simpleCommand()
+	cli, err := ParseCLI(cmd, []string{"arg1"})
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseCLI: %s", err)
+	}
+	if _, err := NewIniSource(path, cli); err == nil {
+		t.Error("Expected error from NewIniSource with an unrecognized key, instead got nil")
+	}
+}
+
+func TestIniSourceInclude(t *testing.T) {
+	dir := t.TempDir()
+	includedPath := filepath.Join(dir, "included.ini")
+	if err := os.WriteFile(includedPath, []byte("hasshort = from included file\n"), 0644); err != nil {
+		t.Fatalf("Unable to write temp INI file: %s", err)
+	}
+	mainPath := filepath.Join(dir, "main.ini")
+	if err := os.WriteFile(mainPath, []byte("include = included.ini\nvisible = from main\n"), 0644); err != nil {
+		t.Fatalf("Unable to write temp INI file: %s", err)
+	}
+
+	cmd := simpleCommand()
+	cli, err := ParseCLI(cmd, []string{"arg1"})
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseCLI: %s", err)
+	}
+	ini, err := NewIniSource(mainPath, cli)
+	if err != nil {
+		t.Fatalf("Unexpected error from NewIniSource: %s", err)
+	}
+	cfg := NewConfig(cli, ini)
+
+	if value := cfg.Get("hasshort"); value != "from included file" {
+		t.Errorf("Expected included file's key to be visible, instead found %q", value)
+	}
+	if value := cfg.Get("visible"); value != "from main" {
+		t.Errorf("Expected main file's own key to still be visible alongside the include, instead found %q", value)
+	}
+}
+
+func TestIniSourceIncludeWithinSection(t *testing.T) {
+	dir := t.TempDir()
+	includedPath := filepath.Join(dir, "one.ini")
+	if err := os.WriteFile(includedPath, []byte("newopt = from included section file\n"), 0644); err != nil {
+		t.Fatalf("Unable to write temp INI file: %s", err)
+	}
+	mainPath := filepath.Join(dir, "main.ini")
+	contents := "[one]\ninclude = one.ini\n"
+	if err := os.WriteFile(mainPath, []byte(contents), 0644); err != nil {
+		t.Fatalf("Unable to write temp INI file: %s", err)
+	}
+
+	suite := simpleCommandSuite()
+	cli, err := ParseCLI(suite, []string{"one"})
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseCLI: %s", err)
+	}
+	ini, err := NewIniSource(mainPath, cli)
+	if err != nil {
+		t.Fatalf("Unexpected error from NewIniSource with an include inside a [section]: %s", err)
+	}
+	cfg := NewConfig(cli, ini)
+
+	if value := cfg.Get("newopt"); value != "from included section file" {
+		t.Errorf("Expected an include inside [one] to inherit that section's scope, instead found %q", value)
+	}
+}
+
+func TestIniSourceIncludeCycle(t *testing.T) {
+	dir := t.TempDir()
+	aPath := filepath.Join(dir, "a.ini")
+	bPath := filepath.Join(dir, "b.ini")
+	if err := os.WriteFile(aPath, []byte("include = b.ini\nvisible = from a\n"), 0644); err != nil {
+		t.Fatalf("Unable to write temp INI file: %s", err)
+	}
+	if err := os.WriteFile(bPath, []byte("include = a.ini\nhasshort = from b\n"), 0644); err != nil {
+		t.Fatalf("Unable to write temp INI file: %s", err)
+	}
+
+	cmd := simpleCommand()
+	cli, err := ParseCLI(cmd, []string{"arg1"})
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseCLI: %s", err)
+	}
+	ini, err := NewIniSource(aPath, cli)
+	if err != nil {
+		t.Fatalf("Unexpected error from NewIniSource with a mutual include cycle: %s", err)
+	}
+	cfg := NewConfig(cli, ini)
+
+	if value := cfg.Get("visible"); value != "from a" {
+		t.Errorf("Expected cyclic include to still resolve a.ini's own key, instead found %q", value)
+	}
+	if value := cfg.Get("hasshort"); value != "from b" {
+		t.Errorf("Expected cyclic include to still resolve b.ini's key once, instead found %q", value)
+	}
+}
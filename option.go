@@ -0,0 +1,103 @@
+package mybase
+
+// OptionType indicates the kind of value an Option holds.
+type OptionType int
+
+// The supported Option value types.
+const (
+	OptionTypeString OptionType = iota
+	OptionTypeBool
+)
+
+// Option represents a single option (flag) that a Command or CommandSuite
+// can accept, either on the command-line or via a Source such as a config
+// file. Options are normally constructed via StringOption or BoolOption and
+// then attached to a Command via Command.AddOption.
+type Option struct {
+	Name         string
+	Shorthand    rune
+	Default      string
+	Description  string
+	Type         OptionType
+	HiddenFlag   bool
+	ValueOptFlag bool
+	choices      []string
+	choicesCI    bool
+}
+
+// StringOption returns a new string-typed Option with the supplied name,
+// optional single-character shorthand (0 if none), default value, and
+// description.
+func StringOption(name string, shorthand rune, defaultValue, description string) *Option {
+	return &Option{
+		Name:        name,
+		Shorthand:   shorthand,
+		Default:     defaultValue,
+		Description: description,
+		Type:        OptionTypeString,
+	}
+}
+
+// BoolOption returns a new bool-typed Option. On the command-line, a bool
+// option is supplied bare (--name or -n) to set it true, or via --skip-name
+// to explicitly set it false.
+func BoolOption(name string, shorthand rune, defaultValue bool, description string) *Option {
+	def := "false"
+	if defaultValue {
+		def = "true"
+	}
+	return &Option{
+		Name:        name,
+		Shorthand:   shorthand,
+		Default:     def,
+		Description: description,
+		Type:        OptionTypeBool,
+	}
+}
+
+// Hidden marks the option as hidden from help text and completion output,
+// while still leaving it valid to supply. It returns the receiver for
+// chaining.
+func (opt *Option) Hidden() *Option {
+	opt.HiddenFlag = true
+	return opt
+}
+
+// ValueOptional marks a string option as accepting a bare flag with no
+// attached value (in which case the option's Default is used), in addition
+// to the normal --name=value or -nvalue forms. It returns the receiver for
+// chaining.
+func (opt *Option) ValueOptional() *Option {
+	opt.ValueOptFlag = true
+	return opt
+}
+
+// Choices restricts the option to a fixed set of valid values, validated at
+// CLI-parse time. It returns the receiver for chaining.
+func (opt *Option) Choices(choices ...string) *Option {
+	opt.choices = choices
+	return opt
+}
+
+// CaseInsensitive relaxes Choices validation (and canonicalization) to
+// ignore case. It returns the receiver for chaining.
+func (opt *Option) CaseInsensitive() *Option {
+	opt.choicesCI = true
+	return opt
+}
+
+// IsHidden returns true if the option was marked hidden via Hidden().
+func (opt *Option) IsHidden() bool {
+	return opt.HiddenFlag
+}
+
+// IsValueOptional returns true if the option was marked via ValueOptional().
+func (opt *Option) IsValueOptional() bool {
+	return opt.ValueOptFlag
+}
+
+// ChoiceList returns the declared choice list for the option, if any, along
+// with whether matching should be case-insensitive.
+func (opt *Option) ChoiceList() ([]string, bool) {
+	return opt.choices, opt.choicesCI
+}
@@ -0,0 +1,48 @@
+package mybase
+
+import "testing"
+
+func TestOptionChoicesCLIValidation(t *testing.T) {
+	cmd := NewCommand("choicecmd", "summary", "description", nil)
+	cmd.AddOption(StringOption("level", 0, "info", "dummy description").Choices("debug", "info", "warn", "error"))
+	cmd.AddOption(StringOption("mode", 0, "Fast", "dummy description").Choices("Fast", "Slow").CaseInsensitive())
+
+	cfg := ParseFakeCLI(t, cmd, "choicecmd --level=warn")
+	if value := cfg.Get("level"); value != "warn" {
+		t.Errorf("Expected Get(\"level\") to return \"warn\", instead found %q", value)
+	}
+
+	// CaseInsensitive() canonicalizes the supplied value to the declared casing
+	cfg = ParseFakeCLI(t, cmd, "choicecmd --mode=slow")
+	if value := cfg.Get("mode"); value != "Slow" {
+		t.Errorf("Expected Get(\"mode\") to return canonical-cased \"Slow\", instead found %q", value)
+	}
+
+	if _, err := ParseCLI(cmd, []string{"--level=nope"}); err == nil {
+		t.Error("Expected error from parsing an option value outside its declared choices, instead got nil")
+	}
+}
+
+func TestOptionChoicesGetNonCLISource(t *testing.T) {
+	cmd := NewCommand("choicecmd", "summary", "description", nil)
+	cmd.AddOption(StringOption("mode", 0, "Fast", "dummy description").Choices("Fast", "Slow").CaseInsensitive())
+
+	// A non-CLI Source can supply a value in any declared casing; Get()
+	// should still canonicalize it, the same as a CLI-supplied value.
+	cfg := ParseFakeCLI(t, cmd, "choicecmd", SimpleSource{"mode": "slow"})
+	if value := cfg.Get("mode"); value != "Slow" {
+		t.Errorf("Expected Get(\"mode\") to return canonical-cased \"Slow\", instead found %q", value)
+	}
+}
+
+func TestOptionChoicesGetEnum(t *testing.T) {
+	cmd := NewCommand("choicecmd", "summary", "description", nil)
+	cmd.AddOption(StringOption("level", 0, "info", "dummy description").Choices("debug", "info", "warn", "error"))
+
+	cfg := ParseFakeCLI(t, cmd, "choicecmd")
+	// GetEnum with no explicit allowed list falls back to the option's own declared choices
+	value, err := cfg.GetEnum("level")
+	if value != "info" || err != nil {
+		t.Errorf("Expected info,nil; found %s,%s", value, err)
+	}
+}
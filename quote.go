@@ -0,0 +1,82 @@
+package mybase
+
+import "strings"
+
+// quoteChars are the characters that may be used to wrap a raw option value
+// in order to preserve leading/trailing whitespace or otherwise-significant
+// characters (such as an unwrapping delimiter) that would normally be
+// trimmed away.
+const quoteChars = "'\"`"
+
+// unquote examines s and, if it is fully wrapped in a matching pair of quote
+// characters (', ", or `), strips those quotes and resolves any backslash
+// escapes in the interior. If s is not fully quote-wrapped, it is returned
+// unmodified, including any backslashes -- this is what allows values like
+// Windows paths or unescaped regexes to pass through Get() untouched.
+func unquote(s string) string {
+	runes := []rune(s)
+	if len(runes) < 2 {
+		return s
+	}
+	quote := runes[0]
+	if !strings.ContainsRune(quoteChars, quote) || runes[len(runes)-1] != quote {
+		return s
+	}
+
+	inner := runes[1 : len(runes)-1]
+	out := make([]rune, 0, len(inner))
+	escaped := false
+	for _, r := range inner {
+		if escaped {
+			out = append(out, r)
+			escaped = false
+			continue
+		}
+		if r == '\\' {
+			escaped = true
+			continue
+		}
+		out = append(out, r)
+	}
+	if escaped { // trailing lone backslash, e.g. "'foo\'" with odd count -- keep it literally
+		out = append(out, '\\')
+	}
+	return string(out)
+}
+
+// splitRespectingQuotes splits s on delimiter, except where the delimiter
+// occurs inside a quoted (', ", or `) region or is backslash-escaped. Each
+// returned field still contains its original quoting/escaping verbatim --
+// callers are expected to run each field through unquote() afterwards.
+func splitRespectingQuotes(s string, delimiter rune) []string {
+	var fields []string
+	var cur []rune
+	var quote rune
+	escaped := false
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			cur = append(cur, r)
+			escaped = false
+		case r == '\\':
+			cur = append(cur, r)
+			escaped = true
+		case quote != 0:
+			cur = append(cur, r)
+			if r == quote {
+				quote = 0
+			}
+		case strings.ContainsRune(quoteChars, r):
+			quote = r
+			cur = append(cur, r)
+		case r == delimiter:
+			fields = append(fields, string(cur))
+			cur = nil
+		default:
+			cur = append(cur, r)
+		}
+	}
+	fields = append(fields, string(cur))
+	return fields
+}
@@ -0,0 +1,29 @@
+package mybase
+
+// OptionValuer is implemented by anything that can supply a raw string
+// value for a named option, independent of whether that option is valid for
+// any particular Command.
+type OptionValuer interface {
+	// OptionValue returns the raw string value configured for optionName,
+	// and a bool indicating whether a value was found at all.
+	OptionValue(optionName string) (string, bool)
+}
+
+// Source represents one layer of configuration fed into NewConfig, such as
+// a config file, environment variables, or an in-memory map. The
+// command-line itself (a *CommandLine) also satisfies this interface, and
+// is always consulted first by Config, ahead of any other Source.
+type Source interface {
+	OptionValuer
+}
+
+// SimpleSource is a bare-bones Source backed by an in-memory map, useful in
+// tests and in small tools that don't need a full file-based Source.
+type SimpleSource map[string]string
+
+// OptionValue returns the value in the map corresponding to optionName, if
+// any.
+func (s SimpleSource) OptionValue(optionName string) (string, bool) {
+	value, ok := s[optionName]
+	return value, ok
+}
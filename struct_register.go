@@ -0,0 +1,160 @@
+package mybase
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// tagAttrs parses a struct field's `mybase:"..."` tag into its bare flags
+// (e.g. "hidden", "required") and its key=value pairs (e.g. "name=foo").
+func tagAttrs(tag string) (attrs map[string]string, flags map[string]bool) {
+	attrs = make(map[string]string)
+	flags = make(map[string]bool)
+	for _, part := range strings.Split(tag, ",") {
+		if eq := strings.IndexByte(part, '='); eq >= 0 {
+			attrs[part[:eq]] = part[eq+1:]
+		} else {
+			flags[part] = true
+		}
+	}
+	return attrs, flags
+}
+
+// fieldOptionName converts a Go struct field name (e.g. "MaxRetries") into
+// the kebab-case option name mybase conventionally uses ("max-retries").
+func fieldOptionName(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			sb.WriteByte('-')
+		}
+		sb.WriteRune(r)
+	}
+	return strings.ToLower(sb.String())
+}
+
+// RegisterStruct walks v -- a pointer to a struct -- via reflection, and
+// for each exported field tagged with `mybase:"..."`, registers an
+// equivalent Option or Arg on cmd. This gives callers the ergonomics of
+// declarative, go-flags-style option definitions without giving up
+// mybase's Config layering.
+//
+// Tag syntax is a comma-separated list of bare flags (hidden,
+// value-optional, required, arg) and key=value pairs (name, short,
+// default, desc, choices -- the latter pipe-delimited). A field tagged
+// "arg" is registered as a positional Arg via AddArg instead of an Option;
+// "required" then controls Arg.Required rather than anything option-related.
+func RegisterStruct(cmd *Command, v interface{}) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		panic(fmt.Errorf("RegisterStruct: v must be a pointer to a struct, got %T", v))
+	}
+	rt := rv.Elem().Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, ok := field.Tag.Lookup("mybase")
+		if !ok {
+			continue
+		}
+		attrs, flags := tagAttrs(tag)
+		name := attrs["name"]
+		if name == "" {
+			name = fieldOptionName(field.Name)
+		}
+
+		if flags["arg"] {
+			cmd.AddArg(name, attrs["default"], flags["required"])
+			continue
+		}
+
+		var shorthand rune
+		if s := attrs["short"]; s != "" {
+			shorthand = []rune(s)[0]
+		}
+
+		var opt *Option
+		if field.Type.Kind() == reflect.Bool {
+			opt = BoolOption(name, shorthand, attrs["default"] == "true", attrs["desc"])
+		} else {
+			opt = StringOption(name, shorthand, attrs["default"], attrs["desc"])
+		}
+		if flags["hidden"] {
+			opt.Hidden()
+		}
+		if flags["value-optional"] {
+			opt.ValueOptional()
+		}
+		if choices, ok := attrs["choices"]; ok && choices != "" {
+			opt.Choices(strings.Split(choices, "|")...)
+		}
+		cmd.AddOption(opt)
+	}
+}
+
+var (
+	uint64Type  = reflect.TypeOf(uint64(0))
+	regexpType  = reflect.TypeOf(&regexp.Regexp{})
+	stringSlice = reflect.TypeOf([]string(nil))
+)
+
+// Populate writes cfg's resolved option/arg values into v -- a pointer to a
+// struct previously passed to RegisterStruct -- based on each tagged
+// field's type: uint64 fields use GetBytes, *regexp.Regexp fields use
+// GetRegexp, []string fields use GetSlice (comma-delimited, not
+// fully-wrapped), fields with a "choices" tag use GetEnum, and plain
+// string/bool fields use Get.
+func (cfg *Config) Populate(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		panic(fmt.Errorf("Populate: v must be a pointer to a struct, got %T", v))
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, ok := field.Tag.Lookup("mybase")
+		if !ok {
+			continue
+		}
+		attrs, _ := tagAttrs(tag)
+		name := attrs["name"]
+		if name == "" {
+			name = fieldOptionName(field.Name)
+		}
+		fv := rv.Field(i)
+
+		switch {
+		case field.Type == uint64Type:
+			n, err := cfg.GetBytes(name)
+			if err != nil {
+				return err
+			}
+			fv.SetUint(n)
+		case field.Type == regexpType:
+			re, err := cfg.GetRegexp(name)
+			if err != nil {
+				return err
+			}
+			fv.Set(reflect.ValueOf(re))
+		case field.Type == stringSlice:
+			fv.Set(reflect.ValueOf(cfg.GetSlice(name, ',', false)))
+		case attrs["choices"] != "":
+			value, err := cfg.GetEnum(name, strings.Split(attrs["choices"], "|")...)
+			if err != nil {
+				return err
+			}
+			fv.SetString(value)
+		case field.Type.Kind() == reflect.Bool:
+			fv.SetBool(cfg.Get(name) == "true")
+		case field.Type.Kind() == reflect.String:
+			fv.SetString(cfg.Get(name))
+		default:
+			return fmt.Errorf("Populate: field %s has unsupported type %s", field.Name, field.Type)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package mybase
+
+import (
+	"reflect"
+	"testing"
+)
+
+type structRegisterTarget struct {
+	Name      string   `mybase:"arg,required"`
+	MaxSize   uint64   `mybase:"name=max-size,default=1024"`
+	Level     string   `mybase:"choices=debug|info|warn"`
+	Verbose   bool     `mybase:"short=v"`
+	Tags      []string `mybase:""`
+	Unrelated string
+}
+
+func TestRegisterStruct(t *testing.T) {
+	cmd := NewCommand("structcmd", "summary", "description", nil)
+	var target structRegisterTarget
+	RegisterStruct(cmd, &target)
+
+	if _, ok := cmd.OptionDefinition("max-size"); !ok {
+		t.Error("Expected RegisterStruct to register option \"max-size\", but it did not")
+	}
+	if _, ok := cmd.OptionDefinition("level"); !ok {
+		t.Error("Expected RegisterStruct to register option \"level\", but it did not")
+	}
+	if _, ok := cmd.OptionDefinition("tags"); !ok {
+		t.Error("Expected RegisterStruct to register option \"tags\", but it did not")
+	}
+	args := cmd.Args()
+	if len(args) != 1 || args[0].Name != "name" || !args[0].Required {
+		t.Errorf("Expected RegisterStruct to register a single required \"name\" arg, instead found %#v", args)
+	}
+}
+
+func TestPopulate(t *testing.T) {
+	cmd := NewCommand("structcmd", "summary", "description", nil)
+	var target structRegisterTarget
+	RegisterStruct(cmd, &target)
+
+	cfg := ParseFakeCLI(t, cmd, "structcmd --max-size=2048 --level=warn -v --tags=a,b myname")
+
+	var populated structRegisterTarget
+	if err := cfg.Populate(&populated); err != nil {
+		t.Fatalf("Unexpected error from Populate: %s", err)
+	}
+
+	if populated.Name != "myname" {
+		t.Errorf("Expected Populate to resolve arg-tagged field Name to \"myname\", instead found %q", populated.Name)
+	}
+	if populated.MaxSize != 2048 {
+		t.Errorf("Expected Populate to resolve MaxSize to 2048, instead found %d", populated.MaxSize)
+	}
+	if populated.Level != "warn" {
+		t.Errorf("Expected Populate to resolve Level via GetEnum to \"warn\", instead found %q", populated.Level)
+	}
+	if !populated.Verbose {
+		t.Error("Expected Populate to resolve Verbose to true")
+	}
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(populated.Tags, expected) {
+		t.Errorf("Expected Populate to resolve Tags to %#v, instead found %#v", expected, populated.Tags)
+	}
+}
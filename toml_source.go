@@ -0,0 +1,208 @@
+package mybase
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// TomlSource is a Source that resolves option values from a TOML document.
+// Top-level bare keys become options on the root Command; "[table]" names
+// correspond to subcommands of a CommandSuite (dotted "[parent.child]" for
+// nested subcommands, same as IniSource); and datetime values are converted
+// to RFC3339 strings so they work with the existing string-based Get.
+//
+// Inline arrays of strings, e.g. tags = ["a", "b"], are re-serialized into
+// the same comma/quote form that Config.GetSlice already knows how to
+// parse, so GetSlice("tags", ',', false) round-trips them correctly.
+type TomlSource struct {
+	values map[string]string
+}
+
+// NewTomlSource parses the TOML document at path, scoped to the command
+// chain resolved by cli, and returns a Source usable with NewConfig.
+func NewTomlSource(path string, cli *CommandLine) (*TomlSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("TomlSource: cannot open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	scope := rootOf(cli.Command)
+	lineNum := 0
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(stripTomlComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			sectionName := strings.TrimSpace(line[1 : len(line)-1])
+			next, ok := resolveSection(rootOf(cli.Command), sectionName)
+			if !ok {
+				return nil, fmt.Errorf("%s:%d: [%s] does not correspond to any subcommand", path, lineNum, sectionName)
+			}
+			scope = next
+			continue
+		}
+
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("%s:%d: expected \"key = value\", found %q", path, lineNum, line)
+		}
+		key := strings.TrimSpace(line[:eq])
+		rawValue := strings.TrimSpace(line[eq+1:])
+
+		if _, ok := scope.OptionDefinition(key); !ok {
+			return nil, fmt.Errorf("%s:%d: %q is not a recognized option for command %q", path, lineNum, key, scope.Name)
+		}
+
+		value, err := parseTomlValue(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %s", path, lineNum, err)
+		}
+		if isAncestorOrSelf(scope, cli.Command) {
+			values[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return &TomlSource{values: values}, nil
+}
+
+// OptionValue implements the Source interface.
+func (s *TomlSource) OptionValue(name string) (string, bool) {
+	value, ok := s.values[name]
+	return value, ok
+}
+
+func stripTomlComment(line string) string {
+	inQuote := rune(0)
+	for i, r := range line {
+		switch {
+		case inQuote != 0:
+			if r == inQuote {
+				inQuote = 0
+			}
+		case r == '"' || r == '\'':
+			inQuote = r
+		case r == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parseTomlValue converts a single TOML scalar or array literal into the
+// raw string representation mybase's Config.Get/GetSlice expect.
+func parseTomlValue(raw string) (string, error) {
+	switch {
+	case strings.HasPrefix(raw, "["):
+		return parseTomlArray(raw)
+	case strings.HasPrefix(raw, `"`) || strings.HasPrefix(raw, "'"):
+		return emptyMarker(unquoteTomlString(raw)), nil
+	default:
+		if t, err := parseTomlDatetime(raw); err == nil {
+			return t, nil
+		}
+		return raw, nil
+	}
+}
+
+func parseTomlArray(raw string) (string, error) {
+	if !strings.HasSuffix(raw, "]") {
+		return "", fmt.Errorf("malformed array literal %q", raw)
+	}
+	inner := strings.TrimSpace(raw[1 : len(raw)-1])
+	if inner == "" {
+		return "", nil
+	}
+
+	var elems []string
+	for _, field := range splitRespectingQuotes(inner, ',') {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		elems = append(elems, unquoteTomlString(field))
+	}
+
+	rendered := make([]string, len(elems))
+	for i, elem := range elems {
+		if elem == "" || strings.ContainsAny(elem, `,"'\`+"`") || strings.TrimSpace(elem) != elem {
+			rendered[i] = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(elem) + `"`
+		} else {
+			rendered[i] = elem
+		}
+	}
+	return strings.Join(rendered, ", "), nil
+}
+
+// unquoteTomlString strips TOML's basic (") or literal (') string quoting
+// and resolves the handful of backslash escapes TOML's basic strings
+// support. Unquoted input is returned unchanged.
+func unquoteTomlString(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+	quote := s[0]
+	if quote != '"' && quote != '\'' || s[len(s)-1] != quote {
+		return s
+	}
+	inner := s[1 : len(s)-1]
+	if quote == '\'' {
+		return inner // TOML literal strings have no escapes
+	}
+
+	var sb strings.Builder
+	escaped := false
+	for _, r := range inner {
+		if escaped {
+			switch r {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			default:
+				sb.WriteRune(r)
+			}
+			escaped = false
+			continue
+		}
+		if r == '\\' {
+			escaped = true
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+var tomlDatetimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseTomlDatetime attempts to parse raw as one of TOML's several
+// date/time literal forms, returning it re-formatted as RFC3339.
+func parseTomlDatetime(raw string) (string, error) {
+	for _, layout := range tomlDatetimeLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.Format(time.RFC3339), nil
+		}
+	}
+	return "", fmt.Errorf("not a datetime")
+}
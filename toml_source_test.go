@@ -0,0 +1,126 @@
+package mybase
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTomlSourceOptionStatus(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	contents := "visible = \"from toml\"\nbool1 = true\ntags = [\"a\", \"b, c\"]\n"
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("Unable to write temp TOML file: %s", err)
+	}
+
+	cmd := simpleCommand()
+	cmd.AddOption(StringOption("tags", 0, "", "dummy description"))
+
+	cli, err := ParseCLI(cmd, []string{"-s", "hello world", "--skip-truthybool", "arg1"})
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseCLI: %s", err)
+	}
+	toml, err := NewTomlSource(path, cli)
+	if err != nil {
+		t.Fatalf("Unexpected error from NewTomlSource: %s", err)
+	}
+	cfg := NewConfig(cli, toml)
+
+	assertOptionStatus := func(name string, expectChanged, expectSupplied, expectOnCLI bool) {
+		t.Helper()
+		if cfg.Changed(name) != expectChanged {
+			t.Errorf("Expected cfg.Changed(%s)==%t, but instead returned %t", name, expectChanged, !expectChanged)
+		}
+		if cfg.Supplied(name) != expectSupplied {
+			t.Errorf("Expected cfg.Supplied(%s)==%t, but instead returned %t", name, expectSupplied, !expectSupplied)
+		}
+		if cfg.OnCLI(name) != expectOnCLI {
+			t.Errorf("Expected cfg.OnCLI(%s)==%t, but instead returned %t", name, expectOnCLI, !expectOnCLI)
+		}
+	}
+
+	// visible and bool1 come only from the TOML source layered beneath the CLI
+	assertOptionStatus("visible", true, true, false)
+	assertOptionStatus("bool1", true, true, false)
+	// hasshort and truthybool still come from the CLI itself, same as without a TomlSource
+	assertOptionStatus("hasshort", true, true, true)
+	assertOptionStatus("truthybool", true, true, true)
+
+	expected := []string{"a", "b, c"}
+	if actual := cfg.GetSlice("tags", ',', false); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected GetSlice(\"tags\",...) to round-trip TOML array as %#v, instead found %#v", expected, actual)
+	}
+}
+
+func TestTomlSourceDottedSection(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	contents := "[one.nested]\nnewopt = \"dotted table value\"\n"
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("Unable to write temp TOML file: %s", err)
+	}
+
+	suite := simpleCommandSuite()
+	cmd1 := suite.SubCommands["one"]
+	nested := NewCommand("nested", "summary", "description", nil)
+	nested.AddOption(StringOption("newopt", 0, "", "dummy description"))
+	cmd1.AddSubCommand(nested)
+
+	cli, err := ParseCLI(suite, []string{"one", "nested"})
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseCLI: %s", err)
+	}
+	toml, err := NewTomlSource(path, cli)
+	if err != nil {
+		t.Fatalf("Unexpected error from NewTomlSource: %s", err)
+	}
+	cfg := NewConfig(cli, toml)
+
+	if value := cfg.Get("newopt"); value != "dotted table value" {
+		t.Errorf("Expected dotted [one.nested] table to scope to the nested subcommand, instead found %q", value)
+	}
+}
+
+func TestTomlSourceUnknownTable(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(path, []byte("[nosuchsub]\nnewopt = \"whatever\"\n"), 0644); err != nil {
+		t.Fatalf("Unable to write temp TOML file: %s", err)
+	}
+
+	suite := simpleCommandSuite()
+	cli, err := ParseCLI(suite, []string{"one"})
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseCLI: %s", err)
+	}
+	if _, err := NewTomlSource(path, cli); err == nil {
+		t.Error("Expected error from NewTomlSource with a table naming a nonexistent subcommand, instead got nil")
+	}
+}
+
+func TestTomlSourceDatetime(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	contents := "visible = 2021-06-01T15:04:05Z\n"
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("Unable to write temp TOML file: %s", err)
+	}
+
+	cmd := simpleCommand()
+	cli, err := ParseCLI(cmd, []string{"arg1"})
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseCLI: %s", err)
+	}
+	toml, err := NewTomlSource(path, cli)
+	if err != nil {
+		t.Fatalf("Unexpected error from NewTomlSource: %s", err)
+	}
+	cfg := NewConfig(cli, toml)
+
+	expected := "2021-06-01T15:04:05Z"
+	if value := cfg.Get("visible"); value != expected {
+		t.Errorf("Expected TOML datetime literal to convert to RFC3339 %q, instead found %q", expected, value)
+	}
+}